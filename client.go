@@ -4,6 +4,7 @@ package hrtclient
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"reflect"
 
@@ -14,10 +15,60 @@ import (
 // Client is a higher-level wrapper around [http.Client] that provides encoding
 // and decoding of data.
 type Client struct {
-	client  *http.Client
-	header  http.Header
-	codec   Codec
-	baseURL string
+	client      *http.Client
+	header      http.Header
+	codec       Codec
+	resolver    Resolver
+	selector    Selector
+	middlewares []ClientMiddleware
+	do          RoundTripFunc
+}
+
+// RoundTripFunc performs a single HTTP round trip. It has the same shape as
+// [http.Client.Do] so that a chain of [ClientMiddleware] can wrap it.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// ClientMiddleware wraps a [RoundTripFunc] to add cross-cutting behavior such
+// as retries, rate limiting, or logging, analogous to an [http.RoundTripper]
+// decorator. Middlewares are applied in the order given to [WithMiddleware]:
+// the first middleware is the outermost, so it sees the request first and the
+// response last.
+type ClientMiddleware func(next RoundTripFunc) RoundTripFunc
+
+// ClientOption configures a [Client] constructed by [NewCustomClient].
+type ClientOption func(*Client)
+
+// WithMiddleware appends the given middlewares to the client's round-trip
+// chain. Middlewares added this way wrap the underlying [http.Client], so they
+// run on every request regardless of which [Codec] is used. The first
+// middleware given is the outermost.
+func WithMiddleware(middlewares ...ClientMiddleware) ClientOption {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// WithTransport sets the [http.RoundTripper] used by the client's underlying
+// [http.Client]. The [http.Client] is copied before [http.Client.Transport] is
+// set, so the one passed into [NewCustomClient] (or [http.DefaultClient]) is
+// left unchanged.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		hc := *c.client
+		hc.Transport = transport
+		c.client = &hc
+	}
+}
+
+// WithHTTPClient sets the [http.Client] used to perform requests. A nil
+// client leaves the default in place. It is mainly useful with
+// [NewResolvingClient], which has no other way to specify one.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(c *Client) {
+		if client != nil {
+			c.client = client
+		}
+	}
 }
 
 // NewClient creates a new client with the given base URL and codec.
@@ -29,16 +80,43 @@ func NewClient(baseURL string, codec Codec) *Client {
 }
 
 // NewCustomClient is like [NewClient], but allows you to specify a custom HTTP
-// client.
-func NewCustomClient(baseURL string, codec Codec, client *http.Client) *Client {
-	if client == nil {
-		client = http.DefaultClient
+// client as well as any [ClientOption]s, such as [WithMiddleware]. It is a
+// thin wrapper around [NewResolvingClient] using a [StaticResolver] of the
+// single given baseURL, so existing callers are unaffected by service
+// discovery support.
+func NewCustomClient(baseURL string, codec Codec, client *http.Client, opts ...ClientOption) *Client {
+	opts = append([]ClientOption{WithHTTPClient(client)}, opts...)
+	return NewResolvingClient(StaticResolver([]string{baseURL}), RoundRobinSelector(), codec, opts...)
+}
+
+// NewResolvingClient creates a new client that resolves its target address on
+// every request via resolver and picks among the results with selector,
+// instead of using a single fixed base URL. This allows plugging in service
+// discovery (e.g. [DNSResolver], or a Consul/etcd-backed [Resolver]) and
+// client-side load balancing (e.g. [RoundRobinSelector], [RandomSelector]).
+//
+// On a transport error or a 5xx response, the request is retried against
+// another node from the same resolution, if one is available; this composes
+// with a retry [ClientMiddleware] such as one from the middleware
+// sub-package, which retries against the same node.
+func NewResolvingClient(resolver Resolver, selector Selector, codec Codec, opts ...ClientOption) *Client {
+	c := &Client{
+		client:   http.DefaultClient,
+		codec:    codec,
+		resolver: resolver,
+		selector: selector,
 	}
-	return &Client{
-		client:  client,
-		codec:   codec,
-		baseURL: baseURL,
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.do = c.client.Do
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		c.do = c.middlewares[i](c.do)
 	}
+
+	return c
 }
 
 // WithHeader returns a new client with the given headers.
@@ -59,38 +137,90 @@ func (c *Client) WithHeader(header http.Header) *Client {
 // Do performs the request with the given method and URL. If requestIn is not nil,
 // it is encoded into the request. If responseOut is not nil, it is decoded into
 // the response.
+//
+// The target node is resolved and picked anew for every call (see
+// [NewResolvingClient]); if the chosen node returns a transport error or a 5xx
+// response, Do retries against another node from the same resolution, if one
+// remains.
 func (c *Client) Do(ctx context.Context, method, path string, requestIn, responseOut any) error {
-	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, nil)
+	nodes, err := c.resolveNodes(ctx)
 	if err != nil {
-		return fmt.Errorf("create request: %w", err)
+		return err
 	}
 
-	if requestIn != nil {
-		if err := c.codec.Encode(req, requestIn); err != nil {
-			return err
+	remaining := nodes
+
+	for {
+		node := c.selector.Pick(remaining)
+
+		req, err := http.NewRequestWithContext(ctx, method, node.Address+path, nil)
+		if err != nil {
+			return fmt.Errorf("create request: %w", err)
 		}
-	}
 
-	for k, v := range c.header {
-		req.Header[k] = v
+		if requestIn != nil {
+			if err := c.codec.Encode(req, requestIn); err != nil {
+				return err
+			}
+		}
+
+		c.applyHeaders(req)
+
+		resp, doErr := c.do(req)
+
+		retryable := doErr != nil || (resp != nil && resp.StatusCode >= 500)
+		if retryable && len(remaining) > 1 {
+			// Drain and close the response we're about to discard so the
+			// underlying connection can be reused.
+			if resp != nil {
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+			}
+			remaining = removeNode(remaining, node)
+			continue
+		}
+		if doErr != nil {
+			return doErr
+		}
+		defer resp.Body.Close()
+
+		return c.codec.Decode(resp, responseOut)
 	}
+}
 
-	h := ctxt.FromOr(ctx, contextHeader{})
-	for k, v := range h.h {
-		req.Header[k] = v
+// resolveNodes resolves the client's nodes via its [Resolver], erroring out
+// if none are returned.
+func (c *Client) resolveNodes(ctx context.Context) ([]Node, error) {
+	nodes, err := c.resolver.Resolve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("resolve endpoint: %w", err)
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("hrtclient: resolver returned no nodes")
 	}
+	return nodes, nil
+}
 
-	resp, err := c.client.Do(req)
+// resolveNode resolves the client's nodes and picks one via its [Selector].
+func (c *Client) resolveNode(ctx context.Context) (Node, error) {
+	nodes, err := c.resolveNodes(ctx)
 	if err != nil {
-		return err
+		return Node{}, err
 	}
-	defer resp.Body.Close()
+	return c.selector.Pick(nodes), nil
+}
 
-	if err := c.codec.Decode(resp, responseOut); err != nil {
-		return err
+// applyHeaders layers the client's static headers and any request-scoped
+// headers from ctx (see [WithHeader]) onto req, in that order.
+func (c *Client) applyHeaders(req *http.Request) {
+	for k, v := range c.header {
+		req.Header[k] = v
 	}
 
-	return nil
+	h := ctxt.FromOr(req.Context(), contextHeader{})
+	for k, v := range h.h {
+		req.Header[k] = v
+	}
 }
 
 type contextHeader struct {
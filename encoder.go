@@ -28,8 +28,6 @@ func (e MethodEncoder) Encode(r *http.Request, v any) error {
 	return ec.Encode(r, v)
 }
 
-// TODO: implement URLEncoder
-
 type validatedEncoder struct{ enc Encoder }
 
 // ValidatedEncoder wraps an encoder and validates the request after encoding it.
@@ -0,0 +1,267 @@
+package hrtclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+
+	"libdb.so/hrt"
+)
+
+// JSONRPCRequest is the envelope encoded by [JSONRPCCodec]. Most users build
+// one indirectly through [JSONRPCEndpoint] rather than constructing it by
+// hand.
+type JSONRPCRequest struct {
+	// Method is the JSON-RPC method name.
+	Method string
+	// Params is encoded as the request's "params" field.
+	Params any
+	// ID overrides the auto-incrementing ID that [JSONRPCCodec] would
+	// otherwise assign. Leave nil to let the codec assign one.
+	ID any
+}
+
+// JSONRPCError represents a JSON-RPC 2.0 error object. [NewJSONRPCError]
+// wraps it into an [hrt.HTTPError] with a status mapped from Code, and it can
+// be recovered from such an error with [errors.As].
+type JSONRPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *JSONRPCError) Error() string {
+	return fmt.Sprintf("jsonrpc: %d: %s", e.Code, e.Message)
+}
+
+// Standard JSON-RPC 2.0 error codes, as defined by the spec.
+const (
+	JSONRPCParseError     = -32700
+	JSONRPCInvalidRequest = -32600
+	JSONRPCMethodNotFound = -32601
+	JSONRPCInvalidParams  = -32602
+	JSONRPCInternalError  = -32603
+)
+
+// jsonRPCErrorStatus maps a JSON-RPC error code to an HTTP status code. Codes
+// outside the standard range map to 500, matching the "internal error"
+// fallback.
+func jsonRPCErrorStatus(code int) int {
+	switch code {
+	case JSONRPCParseError, JSONRPCInvalidRequest, JSONRPCInvalidParams:
+		return http.StatusBadRequest
+	case JSONRPCMethodNotFound:
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+type jsonRPCCodec struct{ nextID *atomic.Int64 }
+
+// JSONRPCCodec is a [Codec] that speaks JSON-RPC 2.0 over HTTP. Encode
+// expects a [JSONRPCRequest] value, which [JSONRPCEndpoint] constructs
+// automatically; Decode unwraps the "result" or "error" field of the
+// response, turning a JSON-RPC error object into an error via
+// [NewJSONRPCError] that wraps a [*JSONRPCError].
+var JSONRPCCodec Codec = NewJSONRPCCodec()
+
+// NewJSONRPCCodec creates a new [JSONRPCCodec]. Unlike the [JSONRPCCodec]
+// package variable, each call returns a codec with its own auto-incrementing
+// ID counter, so use this when multiple [Client]s must not share IDs.
+func NewJSONRPCCodec() Codec {
+	return jsonRPCCodec{nextID: new(atomic.Int64)}
+}
+
+func (c jsonRPCCodec) Encode(r *http.Request, v any) error {
+	req, ok := v.(JSONRPCRequest)
+	if !ok {
+		return fmt.Errorf("hrtclient: JSONRPCCodec requires a JSONRPCRequest, got %T", v)
+	}
+
+	b, err := json.Marshal(jsonRPCRequestWire{
+		JSONRPC: "2.0",
+		Method:  req.Method,
+		Params:  req.Params,
+		ID:      c.resolveID(req.ID),
+	})
+	if err != nil {
+		return fmt.Errorf("encode error: %w", err)
+	}
+
+	r.Header.Set("Content-Type", "application/json")
+	r.ContentLength = int64(len(b))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(b)), nil
+	}
+	r.Body, _ = r.GetBody()
+
+	return nil
+}
+
+func (c jsonRPCCodec) resolveID(id any) any {
+	if id != nil {
+		return id
+	}
+	return c.nextID.Add(1)
+}
+
+func (c jsonRPCCodec) Decode(r *http.Response, v any) error {
+	if r.StatusCode < 200 || r.StatusCode >= 300 {
+		return TextErrorDecoder.Decode(r, nil)
+	}
+
+	var resp jsonRPCResponseWire
+	if err := json.NewDecoder(r.Body).Decode(&resp); err != nil {
+		return fmt.Errorf("decode error: %w", err)
+	}
+
+	if resp.Error != nil {
+		return NewJSONRPCError(resp.Error)
+	}
+
+	if v == nil || len(resp.Result) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Result, v); err != nil {
+		return fmt.Errorf("decode result: %w", err)
+	}
+	return nil
+}
+
+// NewJSONRPCError wraps a [*JSONRPCError] into an [hrt.HTTPError], mapping
+// its code to an HTTP status via a table of the standard JSON-RPC 2.0 codes.
+// The returned error unwraps to rpcErr, so callers can recover it with
+// [errors.As].
+func NewJSONRPCError(rpcErr *JSONRPCError) error {
+	return hrt.WrapHTTPError(jsonRPCErrorStatus(rpcErr.Code), rpcErr)
+}
+
+type jsonRPCRequestWire struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+	ID      any    `json:"id"`
+}
+
+type jsonRPCResponseWire struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *JSONRPCError   `json:"error,omitempty"`
+	ID      any             `json:"id"`
+}
+
+// JSONRPCEndpoint is the JSON-RPC counterpart of [Endpoint]: it defines a
+// [DoFunc] that POSTs a JSON-RPC 2.0 request named method to path. The
+// request's [Client] must use a [JSONRPCCodec] (or one embedding it, e.g.
+// via [CombinedCodec]).
+func JSONRPCEndpoint[ReqT, RespT any](path, method string) DoFunc[ReqT, RespT] {
+	ep := Endpoint[JSONRPCRequest, RespT]("POST", path)
+	return func(ctx context.Context, client *Client, in ReqT) (RespT, error) {
+		return ep(ctx, client, JSONRPCRequest{Method: method, Params: in})
+	}
+}
+
+// JSONRPCCall describes a single call to send as part of a [Client.DoBatch]
+// request.
+type JSONRPCCall struct {
+	// Method is the JSON-RPC method name.
+	Method string
+	// Params is encoded as the call's "params" field.
+	Params any
+	// ID overrides the sequential ID that [Client.DoBatch] would otherwise
+	// assign within the batch. Leave nil to let DoBatch assign one.
+	ID any
+}
+
+// JSONRPCResult is a single response within a [Client.DoBatch] result,
+// correlated back to its [JSONRPCCall] by ID.
+type JSONRPCResult struct {
+	// ID is the ID of the call this result corresponds to.
+	ID any
+	// Result holds the raw "result" field; call [json.Unmarshal] to decode
+	// it into a concrete type.
+	Result json.RawMessage
+	// Error is set if the call failed.
+	Error *JSONRPCError
+}
+
+// DoBatch sends calls as a single JSON-RPC 2.0 batch request (a JSON array)
+// to path, and returns their results in the same order as calls, correlated
+// by ID rather than by response order, as batch responses are not required
+// to preserve request order. Calls without an explicit ID are assigned
+// sequential IDs scoped to this batch.
+func (c *Client) DoBatch(ctx context.Context, path string, calls []JSONRPCCall) ([]JSONRPCResult, error) {
+	wire := make([]jsonRPCRequestWire, len(calls))
+	ids := make([]any, len(calls))
+
+	for i, call := range calls {
+		id := call.ID
+		if id == nil {
+			id = i + 1
+		}
+		ids[i] = id
+		wire[i] = jsonRPCRequestWire{JSONRPC: "2.0", Method: call.Method, Params: call.Params, ID: id}
+	}
+
+	b, err := json.Marshal(wire)
+	if err != nil {
+		return nil, fmt.Errorf("encode batch: %w", err)
+	}
+
+	node, err := c.resolveNode(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, node.Address+path, bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.ContentLength = int64(len(b))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(b)), nil
+	}
+
+	c.applyHeaders(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, TextErrorDecoder.Decode(resp, nil)
+	}
+
+	var wireResults []jsonRPCResponseWire
+	if err := json.NewDecoder(resp.Body).Decode(&wireResults); err != nil {
+		return nil, fmt.Errorf("decode batch: %w", err)
+	}
+
+	byID := make(map[any]jsonRPCResponseWire, len(wireResults))
+	for _, r := range wireResults {
+		byID[fmt.Sprint(r.ID)] = r
+	}
+
+	results := make([]JSONRPCResult, len(calls))
+	for i, id := range ids {
+		r, ok := byID[fmt.Sprint(id)]
+		if !ok {
+			results[i] = JSONRPCResult{ID: id, Error: &JSONRPCError{
+				Code:    JSONRPCInternalError,
+				Message: "no response for this call in the batch",
+			}}
+			continue
+		}
+		results[i] = JSONRPCResult{ID: id, Result: r.Result, Error: r.Error}
+	}
+
+	return results, nil
+}
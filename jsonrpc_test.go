@@ -0,0 +1,152 @@
+package hrtclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"libdb.so/hrtclient"
+)
+
+type addParams struct {
+	A int `json:"a"`
+	B int `json:"b"`
+}
+
+type addResult struct {
+	Sum int `json:"sum"`
+}
+
+func newJSONRPCTestServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rpc", func(w http.ResponseWriter, r *http.Request) {
+		var reqs []struct {
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+			ID     any             `json:"id"`
+		}
+
+		batch := r.Header.Get("X-Batch") == "true"
+		if batch {
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&reqs))
+		} else {
+			var single struct {
+				Method string          `json:"method"`
+				Params json.RawMessage `json:"params"`
+				ID     any             `json:"id"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&single))
+			reqs = append(reqs, single)
+		}
+
+		type resultOrError struct {
+			JSONRPC string `json:"jsonrpc"`
+			Result  any    `json:"result,omitempty"`
+			Error   any    `json:"error,omitempty"`
+			ID      any    `json:"id"`
+		}
+
+		var results []resultOrError
+		for _, req := range reqs {
+			switch req.Method {
+			case "add":
+				var p addParams
+				assert.NoError(t, json.Unmarshal(req.Params, &p))
+				results = append(results, resultOrError{"2.0", addResult{Sum: p.A + p.B}, nil, req.ID})
+			case "boom":
+				results = append(results, resultOrError{"2.0", nil, map[string]any{
+					"code":    -32601,
+					"message": "method not found",
+				}, req.ID})
+			default:
+				t.Fatalf("unexpected method %q", req.Method)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if batch {
+			json.NewEncoder(w).Encode(results)
+		} else {
+			json.NewEncoder(w).Encode(results[0])
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestJSONRPCEndpoint(t *testing.T) {
+	server := newJSONRPCTestServer(t)
+	client := hrtclient.NewClient(server.URL, hrtclient.JSONRPCCodec)
+	ctx := context.Background()
+
+	add := hrtclient.JSONRPCEndpoint[addParams, addResult]("/rpc", "add")
+	resp, err := add(ctx, client, addParams{A: 1, B: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, addResult{Sum: 3}, resp)
+
+	boom := hrtclient.JSONRPCEndpoint[addParams, addResult]("/rpc", "boom")
+	_, err = boom(ctx, client, addParams{})
+	assert.Error(t, err)
+
+	var rpcErr *hrtclient.JSONRPCError
+	assert.True(t, errors.As(err, &rpcErr))
+	assert.Equal(t, -32601, rpcErr.Code)
+}
+
+func TestJSONRPCEndpointHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("<html>503 Service Unavailable</html>"))
+	}))
+	defer server.Close()
+
+	client := hrtclient.NewClient(server.URL, hrtclient.JSONRPCCodec)
+
+	add := hrtclient.JSONRPCEndpoint[addParams, addResult]("/rpc", "add")
+	_, err := add(context.Background(), client, addParams{A: 1, B: 2})
+	assert.Error(t, err)
+	assert.Equal(t, "503: <html>503 Service Unavailable</html>", err.Error())
+}
+
+func TestClientDoBatch(t *testing.T) {
+	server := newJSONRPCTestServer(t)
+	client := hrtclient.NewClient(server.URL, hrtclient.JSONRPCCodec).
+		WithHeader(http.Header{"X-Batch": {"true"}})
+	ctx := context.Background()
+
+	results, err := client.DoBatch(ctx, "/rpc", []hrtclient.JSONRPCCall{
+		{Method: "add", Params: addParams{A: 1, B: 2}},
+		{Method: "add", Params: addParams{A: 10, B: 20}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(results))
+
+	var first, second addResult
+	assert.NoError(t, json.Unmarshal(results[0].Result, &first))
+	assert.NoError(t, json.Unmarshal(results[1].Result, &second))
+	assert.Equal(t, addResult{Sum: 3}, first)
+	assert.Equal(t, addResult{Sum: 30}, second)
+}
+
+func TestClientDoBatchHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("<html>503 Service Unavailable</html>"))
+	}))
+	defer server.Close()
+
+	client := hrtclient.NewClient(server.URL, hrtclient.JSONRPCCodec)
+	ctx := context.Background()
+
+	_, err := client.DoBatch(ctx, "/rpc", []hrtclient.JSONRPCCall{
+		{Method: "add", Params: addParams{A: 1, B: 2}},
+	})
+	assert.Error(t, err)
+	assert.Equal(t, "503: <html>503 Service Unavailable</html>", err.Error())
+}
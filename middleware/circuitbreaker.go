@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"libdb.so/hrtclient"
+)
+
+// BreakerState is the state of a [CircuitBreaker].
+type BreakerState int
+
+const (
+	// BreakerClosed lets requests through, counting failures.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen rejects requests immediately without calling the next
+	// [hrtclient.RoundTripFunc].
+	BreakerOpen
+	// BreakerHalfOpen lets a single trial request through to decide whether
+	// to close the breaker again or reopen it.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// ErrBreakerOpen is returned by a [CircuitBreaker] middleware when the
+// breaker is open and rejecting requests.
+var ErrBreakerOpen = fmt.Errorf("hrtclient: circuit breaker is open")
+
+// CircuitBreakerOptions configures [CircuitBreaker].
+type CircuitBreakerOptions struct {
+	// FailureRatio is the fraction of failed requests, out of all requests
+	// counted since the breaker last closed, that trips the breaker open.
+	// Zero means 0.5.
+	FailureRatio float64
+	// MinRequests is the minimum number of requests observed in the current
+	// window before FailureRatio is evaluated. Zero means 10.
+	MinRequests int
+	// CoolDown is how long the breaker stays open before moving to
+	// half-open and letting a trial request through. Zero means 30s.
+	CoolDown time.Duration
+	// IsFailure reports whether a round trip counts as a failure. The
+	// default treats transport errors and 5xx responses as failures.
+	IsFailure func(resp *http.Response, err error) bool
+}
+
+func (o CircuitBreakerOptions) withDefaults() CircuitBreakerOptions {
+	if o.FailureRatio <= 0 {
+		o.FailureRatio = 0.5
+	}
+	if o.MinRequests <= 0 {
+		o.MinRequests = 10
+	}
+	if o.CoolDown <= 0 {
+		o.CoolDown = 30 * time.Second
+	}
+	if o.IsFailure == nil {
+		o.IsFailure = func(resp *http.Response, err error) bool {
+			return err != nil || resp.StatusCode >= 500
+		}
+	}
+	return o
+}
+
+// CircuitBreaker returns an [hrtclient.ClientMiddleware] implementing a
+// closed/open/half-open circuit breaker. While closed, it tracks the failure
+// ratio of all requests since it last closed, not a sliding window; once at
+// least [CircuitBreakerOptions.MinRequests] requests have been counted and
+// the ratio is met or exceeded, the breaker opens and fails fast with
+// [ErrBreakerOpen] for [CircuitBreakerOptions.CoolDown] before trying a single
+// half-open request to decide whether to close again.
+func CircuitBreaker(opts CircuitBreakerOptions) hrtclient.ClientMiddleware {
+	opts = opts.withDefaults()
+	b := &breaker{opts: opts}
+
+	return func(next hrtclient.RoundTripFunc) hrtclient.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if !b.allow() {
+				return nil, ErrBreakerOpen
+			}
+
+			resp, err := next(req)
+			b.record(!opts.IsFailure(resp, err))
+			return resp, err
+		}
+	}
+}
+
+type breaker struct {
+	opts CircuitBreakerOptions
+
+	mu        sync.Mutex
+	state     BreakerState
+	openUntil time.Time
+	requests  int
+	failures  int
+}
+
+// allow reports whether a request may proceed, transitioning open->half-open
+// once the cool-down has elapsed.
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if time.Now().Before(b.openUntil) {
+			return false
+		}
+		b.state = BreakerHalfOpen
+		return true
+	case BreakerHalfOpen:
+		// Only one trial request is allowed through at a time; treat
+		// concurrent callers as still open.
+		return false
+	default:
+		return true
+	}
+}
+
+// record reports the outcome of a request that was allowed through.
+func (b *breaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerHalfOpen {
+		if success {
+			b.reset()
+		} else {
+			b.trip()
+		}
+		return
+	}
+
+	b.requests++
+	if !success {
+		b.failures++
+	}
+
+	if b.requests >= b.opts.MinRequests &&
+		float64(b.failures)/float64(b.requests) >= b.opts.FailureRatio {
+		b.trip()
+	}
+}
+
+func (b *breaker) trip() {
+	b.state = BreakerOpen
+	b.openUntil = time.Now().Add(b.opts.CoolDown)
+	b.requests, b.failures = 0, 0
+}
+
+func (b *breaker) reset() {
+	b.state = BreakerClosed
+	b.requests, b.failures = 0, 0
+}
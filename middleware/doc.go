@@ -0,0 +1,4 @@
+// Package middleware provides batteries-included [hrtclient.ClientMiddleware]
+// implementations for cross-cutting concerns: retries, rate limiting, circuit
+// breaking, request-ID propagation, and structured logging.
+package middleware
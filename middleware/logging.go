@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"libdb.so/hrtclient"
+)
+
+// Logging returns an [hrtclient.ClientMiddleware] that logs each request's
+// method, URL, status code (or error) and duration to logger at info level,
+// or error level if the round trip itself failed. A nil logger uses
+// [slog.Default].
+func Logging(logger *slog.Logger) hrtclient.ClientMiddleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return func(next hrtclient.RoundTripFunc) hrtclient.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+			dur := time.Since(start)
+
+			if err != nil {
+				logger.Error("hrtclient: request failed",
+					"method", req.Method,
+					"url", req.URL.String(),
+					"duration", dur,
+					"error", err)
+				return resp, err
+			}
+
+			logger.Info("hrtclient: request completed",
+				"method", req.Method,
+				"url", req.URL.String(),
+				"status", resp.StatusCode,
+				"duration", dur)
+			return resp, err
+		}
+	}
+}
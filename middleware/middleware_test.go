@@ -0,0 +1,102 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"libdb.so/hrtclient"
+	"libdb.so/hrtclient/middleware"
+)
+
+// testCodec ignores response bodies; these tests only care about status
+// codes and how many times the server was hit.
+var testCodec = hrtclient.CombinedCodec{
+	Encoder: hrtclient.JSONCodec,
+	Decoder: hrtclient.StatusDecoder{
+		hrtclient.Status2xx: hrtclient.NoDecoder,
+		hrtclient.Status4xx: hrtclient.TextErrorDecoder,
+		hrtclient.Status5xx: hrtclient.TextErrorDecoder,
+	},
+}
+
+func TestRetry(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := hrtclient.NewCustomClient(server.URL, testCodec, nil,
+		hrtclient.WithMiddleware(middleware.Retry(middleware.RetryOptions{
+			MaxAttempts: 3,
+			BaseDelay:   time.Millisecond,
+		})))
+
+	err := client.Do(context.Background(), "GET", "/", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := hrtclient.NewCustomClient(server.URL, testCodec, nil,
+		hrtclient.WithMiddleware(middleware.RateLimit(middleware.RateLimitOptions{
+			Rate:  1000,
+			Burst: 1,
+		})))
+
+	ctx := context.Background()
+	assert.NoError(t, client.Do(ctx, "GET", "/", nil, nil))
+	assert.NoError(t, client.Do(ctx, "GET", "/", nil, nil))
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := hrtclient.NewCustomClient(server.URL, testCodec, nil,
+		hrtclient.WithMiddleware(middleware.CircuitBreaker(middleware.CircuitBreakerOptions{
+			FailureRatio: 0.5,
+			MinRequests:  2,
+			CoolDown:     time.Minute,
+		})))
+
+	ctx := context.Background()
+	assert.Error(t, client.Do(ctx, "GET", "/", nil, nil))
+	assert.Error(t, client.Do(ctx, "GET", "/", nil, nil))
+
+	err := client.Do(ctx, "GET", "/", nil, nil)
+	assert.Error(t, err)
+	assert.Equal(t, middleware.ErrBreakerOpen, err)
+}
+
+func TestRequestID(t *testing.T) {
+	var gotHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Request-ID")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := hrtclient.NewCustomClient(server.URL, testCodec, nil,
+		hrtclient.WithMiddleware(middleware.RequestID("", func() string { return "test-id" })))
+
+	err := client.Do(context.Background(), "GET", "/", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "test-id", gotHeader)
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"libdb.so/hrtclient"
+)
+
+// RateLimitOptions configures [RateLimit].
+type RateLimitOptions struct {
+	// Rate is the number of tokens replenished per second.
+	Rate float64
+	// Burst is the maximum number of tokens the bucket can hold. It is also
+	// the number of requests that may be sent in a burst before the rate
+	// limit kicks in. Zero means 1.
+	Burst int
+}
+
+// RateLimit returns an [hrtclient.ClientMiddleware] that throttles outgoing
+// requests with a token-bucket limiter: [RateLimitOptions.Rate] tokens are
+// added per second up to [RateLimitOptions.Burst], and each request consumes
+// one token, blocking until one is available or the request's context is
+// done.
+func RateLimit(opts RateLimitOptions) hrtclient.ClientMiddleware {
+	if opts.Burst <= 0 {
+		opts.Burst = 1
+	}
+
+	b := &tokenBucket{
+		rate:   opts.Rate,
+		burst:  float64(opts.Burst),
+		tokens: float64(opts.Burst),
+		last:   time.Now(),
+	}
+
+	return func(next hrtclient.RoundTripFunc) hrtclient.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if err := b.take(req.Context()); err != nil {
+				return nil, err
+			}
+			return next(req)
+		}
+	}
+}
+
+// tokenBucket is a simple token-bucket rate limiter.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// take blocks until a token is available or ctx is done.
+func (b *tokenBucket) take(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+
+		wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"libdb.so/hrtclient"
+)
+
+// DefaultRequestIDHeader is the header used by [RequestID] when none is
+// given.
+const DefaultRequestIDHeader = "X-Request-ID"
+
+// RequestID returns an [hrtclient.ClientMiddleware] that sets a request-ID
+// header on every outgoing request, generating one with gen if the header
+// isn't already set. An empty header defaults to [DefaultRequestIDHeader]; a
+// nil gen defaults to a random 16-byte hex string.
+func RequestID(header string, gen func() string) hrtclient.ClientMiddleware {
+	if header == "" {
+		header = DefaultRequestIDHeader
+	}
+	if gen == nil {
+		gen = newRandomRequestID
+	}
+
+	return func(next hrtclient.RoundTripFunc) hrtclient.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(header) == "" {
+				req.Header.Set(header, gen())
+			}
+			return next(req)
+		}
+	}
+}
+
+func newRandomRequestID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,170 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"libdb.so/hrtclient"
+)
+
+// RetryPredicate reports whether a round trip should be retried. resp is nil
+// if the round trip returned err instead of a response.
+type RetryPredicate func(resp *http.Response, err error) bool
+
+// DefaultShouldRetry retries on transport errors and on 429 or 5xx responses.
+func DefaultShouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return resp.StatusCode >= 500
+}
+
+// Default tuning parameters for [Retry].
+const (
+	DefaultMaxAttempts = 3
+	DefaultBaseDelay   = 100 * time.Millisecond
+	DefaultMaxDelay    = 5 * time.Second
+)
+
+// RetryOptions configures [Retry]. The zero value uses the package defaults.
+type RetryOptions struct {
+	// MaxAttempts is the maximum number of attempts, including the first.
+	// Zero means DefaultMaxAttempts.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry, before jitter is
+	// applied; each subsequent retry doubles it. Zero means DefaultBaseDelay.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff delay, before jitter is applied.
+	// Zero means DefaultMaxDelay.
+	MaxDelay time.Duration
+	// ShouldRetry decides whether a response or error warrants a retry.
+	// Nil means DefaultShouldRetry.
+	ShouldRetry RetryPredicate
+}
+
+func (o RetryOptions) withDefaults() RetryOptions {
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = DefaultMaxAttempts
+	}
+	if o.BaseDelay <= 0 {
+		o.BaseDelay = DefaultBaseDelay
+	}
+	if o.MaxDelay <= 0 {
+		o.MaxDelay = DefaultMaxDelay
+	}
+	if o.ShouldRetry == nil {
+		o.ShouldRetry = DefaultShouldRetry
+	}
+	return o
+}
+
+// Retry returns an [hrtclient.ClientMiddleware] that retries failed round
+// trips with exponential backoff and full jitter. It honors the request's
+// context cancellation, prefers a `Retry-After` response header over the
+// computed backoff delay when present, and replays the request body via
+// [http.Request.GetBody] so that encoded bodies (see [hrtclient.Codec]) can be
+// safely resent.
+func Retry(opts RetryOptions) hrtclient.ClientMiddleware {
+	opts = opts.withDefaults()
+
+	return func(next hrtclient.RoundTripFunc) hrtclient.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			var resp *http.Response
+			var err error
+
+			for attempt := 0; attempt < opts.MaxAttempts; attempt++ {
+				if attempt > 0 {
+					req, err = rewindBody(req)
+					if err != nil {
+						return nil, err
+					}
+
+					if err := sleep(req.Context(), retryDelay(resp, attempt, opts)); err != nil {
+						return nil, err
+					}
+				}
+
+				resp, err = next(req)
+				if !opts.ShouldRetry(resp, err) {
+					return resp, err
+				}
+
+				// Drain and close the response we're about to discard so the
+				// underlying connection can be reused for the next attempt.
+				if resp != nil && attempt < opts.MaxAttempts-1 {
+					io.Copy(io.Discard, resp.Body)
+					resp.Body.Close()
+				}
+			}
+
+			return resp, err
+		}
+	}
+}
+
+// rewindBody returns req with its body reset to the beginning via GetBody, so
+// it can be resent on retry. Requests without a body are returned unchanged.
+func rewindBody(req *http.Request) (*http.Request, error) {
+	if req.GetBody == nil {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = body
+	return clone, nil
+}
+
+// retryDelay computes the delay before the given attempt, preferring a
+// server-provided Retry-After header over the exponential backoff schedule.
+func retryDelay(resp *http.Response, attempt int, opts RetryOptions) time.Duration {
+	if resp != nil {
+		if d, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return d
+		}
+	}
+
+	backoff := opts.BaseDelay << (attempt - 1)
+	if backoff > opts.MaxDelay || backoff <= 0 {
+		backoff = opts.MaxDelay
+	}
+
+	// Full jitter: pick a random delay in [0, backoff].
+	return time.Duration(rand.Int63n(int64(backoff) + 1))
+}
+
+// retryAfter parses a Retry-After header value, which is either a number of
+// seconds or an HTTP date.
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t), true
+	}
+	return 0, false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,123 @@
+package hrtclient
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/url"
+	"sync/atomic"
+)
+
+// Node is a single resolved service address, as returned by a [Resolver] and
+// chosen among by a [Selector]. Address is prefixed directly onto a request's
+// path, so it should include a scheme, e.g. "http://10.0.0.5:8080".
+type Node struct {
+	Address string
+	// Weight is an optional hint for weight-aware [Selector]s. Zero means
+	// unweighted.
+	Weight int
+}
+
+// Resolver discovers the set of nodes a [Client] may send requests to, e.g.
+// from DNS, Consul, or etcd. It is called again on every [Client.Do], so
+// implementations that hit a network service should cache as appropriate.
+type Resolver interface {
+	Resolve(ctx context.Context) ([]Node, error)
+}
+
+// Selector picks a single [Node] to send a request to out of the nodes a
+// [Resolver] returned, implementing a client-side load-balancing strategy.
+type Selector interface {
+	Pick(nodes []Node) Node
+}
+
+// StaticResolver returns a [Resolver] that always resolves to the given
+// fixed addresses, unaffected by ctx or time. It is what [NewClient] and
+// [NewCustomClient] use under the hood for their single baseURL.
+func StaticResolver(addrs []string) Resolver {
+	nodes := make([]Node, len(addrs))
+	for i, addr := range addrs {
+		nodes[i] = Node{Address: addr}
+	}
+	return staticResolver(nodes)
+}
+
+type staticResolver []Node
+
+func (s staticResolver) Resolve(context.Context) ([]Node, error) {
+	return s, nil
+}
+
+// DNSResolver returns a [Resolver] that resolves rawURL's host via DNS on
+// every call, returning one [Node] per A/AAAA record with the same scheme,
+// port, and path as rawURL. This is useful for talking directly to a
+// headless Kubernetes service, for example.
+func DNSResolver(rawURL string) Resolver {
+	return dnsResolver{rawURL: rawURL}
+}
+
+type dnsResolver struct{ rawURL string }
+
+func (d dnsResolver) Resolve(ctx context.Context) ([]Node, error) {
+	u, err := url.Parse(d.rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("hrtclient: parse DNS resolver URL: %w", err)
+	}
+
+	ips, err := net.DefaultResolver.LookupHost(ctx, u.Hostname())
+	if err != nil {
+		return nil, fmt.Errorf("hrtclient: resolve %q: %w", u.Hostname(), err)
+	}
+
+	nodes := make([]Node, len(ips))
+	for i, ip := range ips {
+		host := ip
+		if port := u.Port(); port != "" {
+			host = net.JoinHostPort(ip, port)
+		}
+		nodes[i] = Node{Address: u.Scheme + "://" + host + u.Path}
+	}
+	return nodes, nil
+}
+
+// RoundRobinSelector returns a [Selector] that cycles through nodes in order,
+// wrapping around. Each call to [RoundRobinSelector] has its own independent
+// counter.
+func RoundRobinSelector() Selector {
+	return &roundRobinSelector{}
+}
+
+type roundRobinSelector struct{ next atomic.Uint64 }
+
+func (s *roundRobinSelector) Pick(nodes []Node) Node {
+	i := s.next.Add(1) - 1
+	return nodes[i%uint64(len(nodes))]
+}
+
+// RandomSelector returns a [Selector] that picks a uniformly random node on
+// every call.
+func RandomSelector() Selector {
+	return randomSelector{}
+}
+
+type randomSelector struct{}
+
+func (randomSelector) Pick(nodes []Node) Node {
+	return nodes[rand.Intn(len(nodes))]
+}
+
+// removeNode returns nodes with the first occurrence of remove deleted,
+// preserving order.
+func removeNode(nodes []Node, remove Node) []Node {
+	out := make([]Node, 0, len(nodes)-1)
+	removed := false
+	for _, n := range nodes {
+		if !removed && n == remove {
+			removed = true
+			continue
+		}
+		out = append(out, n)
+	}
+	return out
+}
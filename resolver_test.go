@@ -0,0 +1,56 @@
+package hrtclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"libdb.so/hrtclient"
+)
+
+func TestResolvingClientFailover(t *testing.T) {
+	var downHits, upHits atomic.Int32
+
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downHits.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer down.Close()
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upHits.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer up.Close()
+
+	client := hrtclient.NewResolvingClient(
+		hrtclient.StaticResolver([]string{down.URL, up.URL}),
+		hrtclient.RoundRobinSelector(),
+		hrtclient.CombinedCodec{
+			Encoder: hrtclient.JSONCodec,
+			Decoder: hrtclient.StatusDecoder{
+				hrtclient.Status2xx: hrtclient.NoDecoder,
+				hrtclient.Status5xx: hrtclient.TextErrorDecoder,
+			},
+		},
+	)
+
+	err := client.Do(context.Background(), "GET", "/", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), downHits.Load())
+	assert.Equal(t, int32(1), upHits.Load())
+}
+
+func TestRoundRobinSelector(t *testing.T) {
+	nodes := []hrtclient.Node{{Address: "a"}, {Address: "b"}, {Address: "c"}}
+	sel := hrtclient.RoundRobinSelector()
+
+	var picked []string
+	for i := 0; i < 4; i++ {
+		picked = append(picked, sel.Pick(nodes).Address)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "a"}, picked)
+}
@@ -0,0 +1,165 @@
+package hrtclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// StreamDecoder decodes a streaming HTTP response body, invoking onEach for
+// every decoded item until the stream ends, onEach returns an error, or the
+// response body is exhausted. Unlike [Decoder], it does not buffer the whole
+// body before returning.
+type StreamDecoder interface {
+	DecodeStream(resp *http.Response, onEach func(any) error) error
+}
+
+// StreamReconnectError is returned by a [StreamDecoder] to tell
+// [Client.DoStream] to transparently reconnect and resume decoding, rather
+// than returning the error to the caller. [SSEStreamCodec] returns one after
+// every disconnect, per the EventSource auto-reconnect behavior.
+type StreamReconnectError struct {
+	// Delay is how long [Client.DoStream] should wait before reconnecting.
+	Delay time.Duration
+}
+
+func (e *StreamReconnectError) Error() string {
+	return "hrtclient: stream disconnected, reconnecting"
+}
+
+// StreamReconnectHeaders lets a [StreamDecoder] inject headers, such as
+// Last-Event-ID, into the request [Client.DoStream] uses to reconnect after a
+// [*StreamReconnectError].
+type StreamReconnectHeaders interface {
+	ReconnectHeaders() http.Header
+}
+
+// DoStream is the streaming counterpart of [Client.Do]. It performs a single
+// request and hands the response body to the client's [Codec], which must
+// implement [StreamDecoder], to repeatedly decode items and invoke onEach.
+// The response body is not closed until the decode loop returns, unlike Do,
+// which fully buffers the response before decoding.
+//
+// A non-2xx response is never handed to the [StreamDecoder]; instead its body
+// is decoded via [TextErrorDecoder] to produce an error carrying the status
+// code and body text.
+//
+// If the decoder returns a [*StreamReconnectError] (as [SSEStreamCodec]
+// does), DoStream waits out its delay, then transparently reconnects and
+// resumes, carrying over any headers from [StreamReconnectHeaders].
+func (c *Client) DoStream(ctx context.Context, method, path string, requestIn any, onEach func(any) error) error {
+	sd, ok := streamDecoderOf(c.codec)
+	if !ok {
+		return fmt.Errorf("hrtclient: codec %T does not support streaming", c.codec)
+	}
+
+	for {
+		node, err := c.resolveNode(ctx)
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, node.Address+path, nil)
+		if err != nil {
+			return fmt.Errorf("create request: %w", err)
+		}
+
+		if requestIn != nil {
+			if err := c.codec.Encode(req, requestIn); err != nil {
+				return err
+			}
+		}
+
+		if rh, ok := reconnectHeadersOf(c.codec); ok {
+			for k, v := range rh.ReconnectHeaders() {
+				req.Header[k] = v
+			}
+		}
+		c.applyHeaders(req)
+
+		resp, err := c.do(req)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			defer resp.Body.Close()
+			return TextErrorDecoder.Decode(resp, nil)
+		}
+
+		err = func() error {
+			defer resp.Body.Close()
+			return sd.DecodeStream(resp, onEach)
+		}()
+
+		var reconnect *StreamReconnectError
+		if errors.As(err, &reconnect) {
+			if err := sleepCtx(ctx, reconnect.Delay); err != nil {
+				return err
+			}
+			continue
+		}
+		return err
+	}
+}
+
+// sleepCtx blocks for d, or until ctx is done, whichever comes first.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// codecDecoder returns the [Decoder] half of codec, unwrapping a
+// [CombinedCodec] so type assertions (e.g. for [StreamDecoder]) see the
+// concrete decoder rather than the combined struct.
+func codecDecoder(codec Codec) Decoder {
+	if cc, ok := codec.(CombinedCodec); ok {
+		return cc.Decoder
+	}
+	return codec
+}
+
+func streamDecoderOf(codec Codec) (StreamDecoder, bool) {
+	sd, ok := codecDecoder(codec).(StreamDecoder)
+	return sd, ok
+}
+
+func reconnectHeadersOf(codec Codec) (StreamReconnectHeaders, bool) {
+	rh, ok := codecDecoder(codec).(StreamReconnectHeaders)
+	return rh, ok
+}
+
+// Stream is the streaming counterpart of [Endpoint]: it defines a function
+// that performs a [Client.DoStream] call, decoding each item the stream
+// yields into a RespT before calling onEach. It understands the item types
+// yielded by this package's stream codecs: [json.RawMessage] (from
+// [NDJSONStreamCodec]) and [*SSEEvent] (from [SSEStreamCodec]).
+func Stream[ReqT, RespT any](method, path string) func(ctx context.Context, client *Client, in ReqT, onEach func(RespT) error) error {
+	return func(ctx context.Context, client *Client, in ReqT, onEach func(RespT) error) error {
+		return client.DoStream(ctx, method, path, in, func(item any) error {
+			raw, err := streamItemBytes(item)
+			if err != nil {
+				return err
+			}
+
+			var out RespT
+			if err := json.Unmarshal(raw, &out); err != nil {
+				return fmt.Errorf("hrtclient: decode stream item: %w", err)
+			}
+			return onEach(out)
+		})
+	}
+}
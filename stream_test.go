@@ -0,0 +1,119 @@
+package hrtclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"libdb.so/hrt"
+	"libdb.so/hrtclient"
+)
+
+type numItem struct {
+	N int `json:"n"`
+}
+
+func newNDJSONTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher := w.(http.Flusher)
+		for i := 1; i <= 3; i++ {
+			fmt.Fprintf(w, `{"n":%d}`+"\n", i)
+			flusher.Flush()
+		}
+	}))
+}
+
+func TestDoStreamNDJSON(t *testing.T) {
+	server := newNDJSONTestServer()
+	defer server.Close()
+
+	client := hrtclient.NewClient(server.URL, hrtclient.NDJSONStreamCodec)
+
+	var got []int
+	err := client.DoStream(context.Background(), "GET", "/", nil, func(item any) error {
+		var v numItem
+		if err := json.Unmarshal(item.(json.RawMessage), &v); err != nil {
+			return err
+		}
+		got = append(got, v.N)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, got)
+}
+
+func TestDoStreamHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error":"not authorized"}`)
+	}))
+	defer server.Close()
+
+	client := hrtclient.NewClient(server.URL, hrtclient.NDJSONStreamCodec)
+
+	var called bool
+	err := client.DoStream(context.Background(), "GET", "/", nil, func(item any) error {
+		called = true
+		return nil
+	})
+
+	assert.Error(t, err)
+	assert.False(t, called)
+	assert.Equal(t, `401: {"error":"not authorized"}`, err.Error())
+}
+
+func TestStreamTyped(t *testing.T) {
+	server := newNDJSONTestServer()
+	defer server.Close()
+
+	client := hrtclient.NewClient(server.URL, hrtclient.NDJSONStreamCodec)
+	stream := hrtclient.Stream[hrt.None, numItem]("GET", "/")
+
+	var got []int
+	err := stream(context.Background(), client, hrt.Empty, func(item numItem) error {
+		got = append(got, item.N)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, got)
+}
+
+var errStopStream = errors.New("stop")
+
+func TestSSEStreamCodec(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher := w.(http.Flusher)
+		fmt.Fprint(w, "id: 1\nevent: greeting\ndata: hello\n\n")
+		flusher.Flush()
+		fmt.Fprint(w, "id: 2\ndata: world\n\n")
+		flusher.Flush()
+	}))
+	defer server.Close()
+
+	client := hrtclient.NewClient(server.URL, hrtclient.SSEStreamCodec)
+
+	var events []*hrtclient.SSEEvent
+	err := client.DoStream(context.Background(), "GET", "/", nil, func(item any) error {
+		ev := item.(*hrtclient.SSEEvent)
+		events = append(events, ev)
+		if len(events) == 2 {
+			return errStopStream
+		}
+		return nil
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, errStopStream, err)
+	assert.Equal(t, 2, len(events))
+	assert.Equal(t, "greeting", events[0].Event)
+	assert.Equal(t, "1", events[0].ID)
+	assert.Equal(t, "hello", string(events[0].Data))
+	assert.Equal(t, "2", events[1].ID)
+}
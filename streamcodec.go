@@ -0,0 +1,172 @@
+package hrtclient
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// streamItemBytes extracts the raw payload from an item yielded by one of
+// this package's [StreamDecoder]s, for use by [Stream].
+func streamItemBytes(item any) ([]byte, error) {
+	switch v := item.(type) {
+	case json.RawMessage:
+		return v, nil
+	case *SSEEvent:
+		return v.Data, nil
+	default:
+		return nil, fmt.Errorf("hrtclient: Stream: unsupported stream item type %T", item)
+	}
+}
+
+type ndjsonStreamCodec struct{}
+
+// NDJSONStreamCodec is a [Codec] for newline-delimited JSON streams, where
+// every non-blank line of the response body is a JSON value. Its
+// [StreamDecoder.DecodeStream] calls onEach with each line as a
+// [json.RawMessage]; its [Decoder.Decode] is the same as [JSONCodec], for
+// non-streaming use via [Client.Do].
+var NDJSONStreamCodec Codec = ndjsonStreamCodec{}
+
+func (ndjsonStreamCodec) Encode(r *http.Request, v any) error {
+	return jsonCodec{}.Encode(r, v)
+}
+
+func (ndjsonStreamCodec) Decode(r *http.Response, v any) error {
+	return jsonCodec{}.Decode(r, v)
+}
+
+func (ndjsonStreamCodec) DecodeStream(r *http.Response, onEach func(any) error) error {
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		raw := make(json.RawMessage, len(line))
+		copy(raw, line)
+
+		if err := onEach(raw); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// SSEEvent is a single parsed Server-Sent Event, as yielded by
+// [SSEStreamCodec].
+type SSEEvent struct {
+	// Event is the event's "event:" field, or "" if absent (the spec's
+	// implied "message" event).
+	Event string
+	// ID is the event's "id:" field, or "" if absent.
+	ID string
+	// Data is the event's "data:" field(s) joined by "\n".
+	Data json.RawMessage
+}
+
+type sseStreamCodec struct {
+	lastEventID atomic.Pointer[string]
+	retry       atomic.Int64 // last "retry:" interval seen, as a time.Duration
+}
+
+// NewSSEStreamCodec creates a new [SSEStreamCodec]. Unlike the
+// [SSEStreamCodec] package variable, each call returns a codec with its own
+// Last-Event-ID state, so use this when multiple [Client]s must not share
+// reconnect state.
+func NewSSEStreamCodec() Codec {
+	return &sseStreamCodec{}
+}
+
+// SSEStreamCodec is a [Codec] that parses a response body as a stream of
+// Server-Sent Events, per the EventSource spec: "event:", "data:", "id:",
+// and "retry:" fields are recognized, multi-line "data:" fields are joined
+// with "\n", and [Client.DoStream] auto-reconnects with a "Last-Event-ID"
+// header on disconnect, honoring the most recently seen "retry:" interval.
+// Its [StreamDecoder.DecodeStream] calls onEach with each event as a
+// [*SSEEvent].
+var SSEStreamCodec Codec = NewSSEStreamCodec()
+
+func (c *sseStreamCodec) Encode(r *http.Request, v any) error {
+	return jsonCodec{}.Encode(r, v)
+}
+
+func (c *sseStreamCodec) Decode(r *http.Response, v any) error {
+	return fmt.Errorf("hrtclient: SSEStreamCodec does not support Decode; use Client.DoStream")
+}
+
+// ReconnectHeaders implements [StreamReconnectHeaders].
+func (c *sseStreamCodec) ReconnectHeaders() http.Header {
+	id := c.lastEventID.Load()
+	if id == nil || *id == "" {
+		return nil
+	}
+	return http.Header{"Last-Event-ID": {*id}}
+}
+
+func (c *sseStreamCodec) DecodeStream(r *http.Response, onEach func(any) error) error {
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	var event, id string
+	var data [][]byte
+	retry := time.Duration(c.retry.Load())
+
+	flush := func() error {
+		if event == "" && id == "" && data == nil {
+			return nil
+		}
+		if id != "" {
+			id := id
+			c.lastEventID.Store(&id)
+		}
+		ev := &SSEEvent{Event: event, ID: id, Data: bytes.Join(data, []byte("\n"))}
+		event, id, data = "", "", nil
+		return onEach(ev)
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if err := flush(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			data = append(data, []byte(value))
+		case "id":
+			id = value
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil {
+				retry = time.Duration(ms) * time.Millisecond
+				c.retry.Store(int64(retry))
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return &StreamReconnectError{Delay: retry}
+	}
+
+	// The server closed the connection without an explicit end-of-stream
+	// signal; per the EventSource spec, the client should reconnect.
+	return &StreamReconnectError{Delay: retry}
+}
@@ -0,0 +1,201 @@
+package hrtclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"libdb.so/hrt"
+)
+
+// TypedErrorDecoder decodes an error response body into a concrete Go error
+// type, registered ahead of time via [TypedErrorDecoder.Register] and/or
+// [TypedErrorDecoder.RegisterStatus], so that callers can recover it with
+// [errors.As] instead of inspecting an error string. It peeks the body once
+// to find an application-level code at CodeField (a dot-separated path, e.g.
+// "error.code" for `{"error":{"code":"..."}}`), then decodes the body again
+// into the type registered for that code, falling back to status-keyed
+// registrations, and finally to a plain [NewHTTPError].
+type TypedErrorDecoder struct {
+	// CodeField is the dot-separated path to the application-level error
+	// code within the response body, e.g. "error.code".
+	CodeField string
+
+	byCode   map[string]func() error
+	byStatus map[int]func() error
+}
+
+// NewTypedErrorDecoder creates a [TypedErrorDecoder] that reads the
+// application-level error code from codeField.
+func NewTypedErrorDecoder(codeField string) *TypedErrorDecoder {
+	return &TypedErrorDecoder{CodeField: codeField}
+}
+
+// Register associates code, as found at [TypedErrorDecoder.CodeField], with
+// newErr, called to allocate a fresh error value to decode the body into. It
+// returns d for chaining.
+func (d *TypedErrorDecoder) Register(code string, newErr func() error) *TypedErrorDecoder {
+	if d.byCode == nil {
+		d.byCode = make(map[string]func() error)
+	}
+	d.byCode[code] = newErr
+	return d
+}
+
+// RegisterStatus associates an HTTP status code with newErr, used when no
+// code registered via [TypedErrorDecoder.Register] matches. It returns d for
+// chaining.
+func (d *TypedErrorDecoder) RegisterStatus(status int, newErr func() error) *TypedErrorDecoder {
+	if d.byStatus == nil {
+		d.byStatus = make(map[int]func() error)
+	}
+	d.byStatus[status] = newErr
+	return d
+}
+
+func (d *TypedErrorDecoder) Decode(r *http.Response, v any) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("read body error: %w", err)
+	}
+
+	newErr := d.byCode[peekCodeField(body, d.CodeField)]
+	if newErr == nil {
+		newErr = d.byStatus[r.StatusCode]
+	}
+	if newErr == nil {
+		return NewHTTPError(r.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	target := newErr()
+	if err := json.Unmarshal(body, target); err != nil {
+		return fmt.Errorf("decode typed error: %w", err)
+	}
+	return hrt.WrapHTTPError(r.StatusCode, target)
+}
+
+// peekCodeField decodes body as generic JSON and walks path, a
+// dot-separated sequence of object keys, returning the string found there,
+// or "" if path doesn't resolve to a string.
+func peekCodeField(body []byte, path string) string {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return ""
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return ""
+		}
+		v = m[key]
+	}
+
+	s, _ := v.(string)
+	return s
+}
+
+// ProblemDetails is an RFC 7807 "application/problem+json" error, as decoded
+// by [MultiErrorDecoder]. Members outside the standard five are captured in
+// Extensions.
+type ProblemDetails struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]any
+}
+
+func (p *ProblemDetails) Error() string {
+	if p.Detail != "" {
+		return fmt.Sprintf("%s: %s", p.Title, p.Detail)
+	}
+	return p.Title
+}
+
+// HTTPStatus implements [hrt.HTTPError].
+func (p *ProblemDetails) HTTPStatus() int { return p.Status }
+
+var problemDetailsFields = map[string]bool{
+	"type": true, "title": true, "status": true, "detail": true, "instance": true,
+}
+
+// UnmarshalJSON decodes the standard RFC 7807 members into their fields and
+// captures any other top-level members into Extensions.
+func (p *ProblemDetails) UnmarshalJSON(data []byte) error {
+	var standard struct {
+		Type     string `json:"type"`
+		Title    string `json:"title"`
+		Status   int    `json:"status"`
+		Detail   string `json:"detail"`
+		Instance string `json:"instance"`
+	}
+	if err := json.Unmarshal(data, &standard); err != nil {
+		return err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var extensions map[string]any
+	for key, v := range raw {
+		if problemDetailsFields[key] {
+			continue
+		}
+		if extensions == nil {
+			extensions = make(map[string]any, len(raw))
+		}
+		var val any
+		if err := json.Unmarshal(v, &val); err != nil {
+			return err
+		}
+		extensions[key] = val
+	}
+
+	p.Type = standard.Type
+	p.Title = standard.Title
+	p.Status = standard.Status
+	p.Detail = standard.Detail
+	p.Instance = standard.Instance
+	p.Extensions = extensions
+	return nil
+}
+
+// MultiErrorDecoder is a [Decoder] that recognizes more than one error
+// response shape: an "application/problem+json" body (RFC 7807) is decoded
+// into a [*ProblemDetails]; anything else falls through to Fallback.
+type MultiErrorDecoder struct {
+	// Fallback decodes error responses that aren't problem+json. A nil
+	// Fallback decodes them with [NewHTTPError] on the raw body.
+	Fallback Decoder
+}
+
+// NewMultiErrorDecoder creates a [MultiErrorDecoder] that falls through to
+// fallback for non-problem+json error responses.
+func NewMultiErrorDecoder(fallback Decoder) *MultiErrorDecoder {
+	return &MultiErrorDecoder{Fallback: fallback}
+}
+
+func (d *MultiErrorDecoder) Decode(r *http.Response, v any) error {
+	contentType, _, _ := strings.Cut(r.Header.Get("Content-Type"), ";")
+	if contentType != "application/problem+json" {
+		if d.Fallback != nil {
+			return d.Fallback.Decode(r, v)
+		}
+		return TextErrorDecoder.Decode(r, v)
+	}
+
+	var problem ProblemDetails
+	if err := json.NewDecoder(r.Body).Decode(&problem); err != nil {
+		return fmt.Errorf("decode problem details: %w", err)
+	}
+	if problem.Status == 0 {
+		problem.Status = r.StatusCode
+	}
+	return &problem
+}
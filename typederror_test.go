@@ -0,0 +1,126 @@
+package hrtclient_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"libdb.so/hrtclient"
+)
+
+type userNotFoundError struct {
+	Error_ struct {
+		Code string `json:"code"`
+	} `json:"error"`
+	UserID string `json:"user_id"`
+}
+
+func (e *userNotFoundError) Error() string {
+	return "user not found: " + e.UserID
+}
+
+func TestTypedErrorDecoder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"code":"USER_NOT_FOUND"},"user_id":"u123"}`))
+	}))
+	defer server.Close()
+
+	decoder := hrtclient.NewTypedErrorDecoder("error.code").
+		Register("USER_NOT_FOUND", func() error { return &userNotFoundError{} })
+
+	client := hrtclient.NewClient(server.URL, hrtclient.CombinedCodec{
+		Encoder: hrtclient.JSONCodec,
+		Decoder: hrtclient.StatusDecoder{
+			hrtclient.Status2xx: hrtclient.NoDecoder,
+			hrtclient.Status4xx: decoder,
+		},
+	})
+
+	err := client.Do(context.Background(), "GET", "/", nil, nil)
+	assert.Error(t, err)
+
+	var notFound *userNotFoundError
+	assert.True(t, errors.As(err, &notFound))
+	assert.Equal(t, "u123", notFound.UserID)
+}
+
+func TestTypedErrorDecoderUnregisteredFallsThrough(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error":{"code":"SOMETHING_ELSE"}}`))
+	}))
+	defer server.Close()
+
+	decoder := hrtclient.NewTypedErrorDecoder("error.code").
+		Register("USER_NOT_FOUND", func() error { return &userNotFoundError{} })
+
+	client := hrtclient.NewClient(server.URL, hrtclient.CombinedCodec{
+		Encoder: hrtclient.JSONCodec,
+		Decoder: hrtclient.StatusDecoder{
+			hrtclient.Status2xx: hrtclient.NoDecoder,
+			hrtclient.Status5xx: decoder,
+		},
+	})
+
+	err := client.Do(context.Background(), "GET", "/", nil, nil)
+	assert.Error(t, err)
+
+	var notFound *userNotFoundError
+	assert.False(t, errors.As(err, &notFound))
+}
+
+func TestMultiErrorDecoderProblemDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{
+			"type": "https://example.com/probs/out-of-credit",
+			"title": "You do not have enough credit.",
+			"status": 400,
+			"detail": "Your current balance is 30, but that costs 50.",
+			"balance": 30
+		}`))
+	}))
+	defer server.Close()
+
+	client := hrtclient.NewClient(server.URL, hrtclient.CombinedCodec{
+		Encoder: hrtclient.JSONCodec,
+		Decoder: hrtclient.StatusDecoder{
+			hrtclient.Status2xx: hrtclient.NoDecoder,
+			hrtclient.Status4xx: hrtclient.NewMultiErrorDecoder(hrtclient.TextErrorDecoder),
+		},
+	})
+
+	err := client.Do(context.Background(), "GET", "/", nil, nil)
+	assert.Error(t, err)
+
+	var problem *hrtclient.ProblemDetails
+	assert.True(t, errors.As(err, &problem))
+	assert.Equal(t, "You do not have enough credit.", problem.Title)
+	assert.Equal(t, 400, problem.Status)
+	assert.Equal(t, any(float64(30)), problem.Extensions["balance"])
+}
+
+func TestMultiErrorDecoderFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("something broke"))
+	}))
+	defer server.Close()
+
+	client := hrtclient.NewClient(server.URL, hrtclient.CombinedCodec{
+		Encoder: hrtclient.JSONCodec,
+		Decoder: hrtclient.StatusDecoder{
+			hrtclient.Status2xx: hrtclient.NoDecoder,
+			hrtclient.Status5xx: hrtclient.NewMultiErrorDecoder(hrtclient.TextErrorDecoder),
+		},
+	})
+
+	err := client.Do(context.Background(), "GET", "/", nil, nil)
+	assert.Error(t, err)
+	assert.Equal(t, "500: something broke", err.Error())
+}
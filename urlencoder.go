@@ -0,0 +1,443 @@
+package hrtclient
+
+import (
+	"encoding"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// URLEncoder is an [Encoder] that populates a request's URL path, query
+// string, and headers by reflecting over a struct's fields and their tags:
+//
+//   - `path:"name"` substitutes "{name}" in the request's URL path.
+//   - `query:"name"` (or `query:"name,omitempty"`) appends name to the query
+//     string. Slice fields are encoded as repeated query values.
+//   - `header:"Name"` (or `header:"Name,omitempty"`) sets a request header.
+//
+// Supported field types are strings, bools, numbers, [time.Time] (encoded as
+// RFC3339), [encoding.TextMarshaler], slices thereof (query only), and
+// pointers to any of the above, where a nil pointer is treated as the zero
+// value for omitempty purposes.
+//
+// For example:
+//
+//	type GetUserReq struct {
+//		ID      int      `path:"id"`
+//		Include []string `query:"include,omitempty"`
+//	}
+//
+//	GET := hrtclient.GET[GetUserReq, User]("/users/{id}")
+//	GET(ctx, client, GetUserReq{ID: 42, Include: []string{"posts"}})
+//	// GET /users/42?include=posts
+var URLEncoder Encoder = urlEncoder{}
+
+type urlEncoder struct{}
+
+func (urlEncoder) Encode(r *http.Request, v any) error {
+	rv, err := structValue(v)
+	if err != nil {
+		return err
+	}
+	if !rv.IsValid() {
+		return nil
+	}
+
+	path := r.URL.Path
+	query := r.URL.Query()
+
+	err = walkTaggedFields(rv, taggedFieldVisitors{
+		path: func(name string, fv reflect.Value) error {
+			str, _, err := stringify(fv)
+			if err != nil {
+				return err
+			}
+			path = strings.ReplaceAll(path, "{"+name+"}", url.PathEscape(str))
+			return nil
+		},
+		query: func(name string, omitempty bool, fv reflect.Value) error {
+			return encodeQueryField(query, name, fv, omitempty)
+		},
+		header: func(name string, omitempty bool, fv reflect.Value) error {
+			str, isZero, err := stringify(fv)
+			if err != nil {
+				return err
+			}
+			if omitempty && isZero {
+				return nil
+			}
+			r.Header.Set(name, str)
+			return nil
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("hrtclient: URLEncoder: %w", err)
+	}
+
+	r.URL.Path = path
+	r.URL.RawQuery = query.Encode()
+	return nil
+}
+
+// FormEncoder is an [Encoder] that encodes a request body as
+// "application/x-www-form-urlencoded", using the same `path`, `query`, and
+// `header` struct tags as [URLEncoder]: `path` still substitutes into the
+// URL, `header` still sets headers, but `query`-tagged fields are written
+// into the form body instead of the URL's query string.
+var FormEncoder Encoder = formEncoder{}
+
+type formEncoder struct{}
+
+func (formEncoder) Encode(r *http.Request, v any) error {
+	rv, err := structValue(v)
+	if err != nil {
+		return err
+	}
+	if !rv.IsValid() {
+		return nil
+	}
+
+	path := r.URL.Path
+	form := url.Values{}
+
+	err = walkTaggedFields(rv, taggedFieldVisitors{
+		path: func(name string, fv reflect.Value) error {
+			str, _, err := stringify(fv)
+			if err != nil {
+				return err
+			}
+			path = strings.ReplaceAll(path, "{"+name+"}", url.PathEscape(str))
+			return nil
+		},
+		query: func(name string, omitempty bool, fv reflect.Value) error {
+			return encodeQueryField(form, name, fv, omitempty)
+		},
+		header: func(name string, omitempty bool, fv reflect.Value) error {
+			str, isZero, err := stringify(fv)
+			if err != nil {
+				return err
+			}
+			if omitempty && isZero {
+				return nil
+			}
+			r.Header.Set(name, str)
+			return nil
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("hrtclient: FormEncoder: %w", err)
+	}
+
+	r.URL.Path = path
+	body := form.Encode()
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.ContentLength = int64(len(body))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(body)), nil
+	}
+	r.Body, _ = r.GetBody()
+
+	return nil
+}
+
+// MultipartEncoder is an [Encoder] that encodes a request body as
+// "multipart/form-data". It uses the same `path`, `query`, and `header` tags
+// as [FormEncoder] for non-file fields, plus `multipart:"name"` on
+// [io.Reader] or [*os.File] fields to attach them as file parts.
+var MultipartEncoder Encoder = multipartEncoder{}
+
+type multipartEncoder struct{}
+
+func (multipartEncoder) Encode(r *http.Request, v any) error {
+	rv, err := structValue(v)
+	if err != nil {
+		return err
+	}
+	if !rv.IsValid() {
+		return nil
+	}
+
+	path := r.URL.Path
+
+	var buf strings.Builder
+	w := multipart.NewWriter(&buf)
+
+	err = walkTaggedFields(rv, taggedFieldVisitors{
+		path: func(name string, fv reflect.Value) error {
+			str, _, err := stringify(fv)
+			if err != nil {
+				return err
+			}
+			path = strings.ReplaceAll(path, "{"+name+"}", url.PathEscape(str))
+			return nil
+		},
+		query: func(name string, omitempty bool, fv reflect.Value) error {
+			values := url.Values{}
+			if err := encodeQueryField(values, name, fv, omitempty); err != nil {
+				return err
+			}
+			for _, str := range values[name] {
+				if err := w.WriteField(name, str); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		header: func(name string, omitempty bool, fv reflect.Value) error {
+			str, isZero, err := stringify(fv)
+			if err != nil {
+				return err
+			}
+			if omitempty && isZero {
+				return nil
+			}
+			r.Header.Set(name, str)
+			return nil
+		},
+		multipart: func(name string, fv reflect.Value) error {
+			return writeMultipartFile(w, name, fv)
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("hrtclient: MultipartEncoder: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("hrtclient: MultipartEncoder: %w", err)
+	}
+
+	r.URL.Path = path
+	body := buf.String()
+	r.Header.Set("Content-Type", w.FormDataContentType())
+	r.ContentLength = int64(len(body))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(body)), nil
+	}
+	r.Body, _ = r.GetBody()
+
+	return nil
+}
+
+func writeMultipartFile(w *multipart.Writer, name string, fv reflect.Value) error {
+	if fv.Kind() == reflect.Pointer && fv.IsNil() {
+		return nil
+	}
+
+	var rdr io.Reader
+	var filename = name
+
+	switch f := fv.Interface().(type) {
+	case *os.File:
+		rdr = f
+		filename = f.Name()
+	case io.Reader:
+		rdr = f
+	default:
+		return fmt.Errorf("field %q: unsupported multipart type %s", name, fv.Type())
+	}
+
+	part, err := w.CreateFormFile(name, filename)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(part, rdr)
+	return err
+}
+
+// taggedFieldVisitors holds a callback per supported struct tag, used by
+// walkTaggedFields to dispatch each tagged field of a request struct.
+type taggedFieldVisitors struct {
+	path      func(name string, fv reflect.Value) error
+	query     func(name string, omitempty bool, fv reflect.Value) error
+	header    func(name string, omitempty bool, fv reflect.Value) error
+	multipart func(name string, fv reflect.Value) error
+}
+
+// walkTaggedFields iterates over rv's exported fields, dispatching each one
+// tagged with "path", "query", "header", or "multipart" to the matching
+// visitor. A nil visitor silently skips fields tagged for it.
+func walkTaggedFields(rv reflect.Value, visitors taggedFieldVisitors) error {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+
+		if tag, ok := field.Tag.Lookup("path"); ok {
+			if visitors.path == nil {
+				continue
+			}
+			name, _ := parseTag(tag)
+			if err := visitors.path(name, fv); err != nil {
+				return fmt.Errorf("field %q: %w", field.Name, err)
+			}
+			continue
+		}
+
+		if tag, ok := field.Tag.Lookup("query"); ok {
+			if visitors.query == nil {
+				continue
+			}
+			name, omitempty := parseTag(tag)
+			if err := visitors.query(name, omitempty, fv); err != nil {
+				return fmt.Errorf("field %q: %w", field.Name, err)
+			}
+			continue
+		}
+
+		if tag, ok := field.Tag.Lookup("header"); ok {
+			if visitors.header == nil {
+				continue
+			}
+			name, omitempty := parseTag(tag)
+			if err := visitors.header(name, omitempty, fv); err != nil {
+				return fmt.Errorf("field %q: %w", field.Name, err)
+			}
+			continue
+		}
+
+		if tag, ok := field.Tag.Lookup("multipart"); ok {
+			if visitors.multipart == nil {
+				continue
+			}
+			name, _ := parseTag(tag)
+			if err := visitors.multipart(name, fv); err != nil {
+				return fmt.Errorf("field %q: %w", field.Name, err)
+			}
+			continue
+		}
+	}
+	return nil
+}
+
+// parseTag splits a struct tag value into its name and whether "omitempty"
+// was among its comma-separated options.
+func parseTag(tag string) (name string, omitempty bool) {
+	parts := strings.Split(tag, ",")
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+	return parts[0], omitempty
+}
+
+// structValue dereferences v down to its underlying struct value. It returns
+// an invalid [reflect.Value] (ok to pass to IsValid) if v is a nil pointer.
+func structValue(v any) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return reflect.Value{}, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("hrtclient: expected a struct, got %s", rv.Kind())
+	}
+	return rv, nil
+}
+
+// encodeQueryField encodes fv into values under name, expanding slices into
+// repeated values and honoring omitempty for empty/nil fields.
+func encodeQueryField(values url.Values, name string, fv reflect.Value, omitempty bool) error {
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			return nil
+		}
+		fv = fv.Elem()
+	}
+
+	if fv.Kind() == reflect.Slice || fv.Kind() == reflect.Array {
+		if fv.Len() == 0 {
+			return nil
+		}
+		for i := 0; i < fv.Len(); i++ {
+			str, _, err := stringify(fv.Index(i))
+			if err != nil {
+				return err
+			}
+			values.Add(name, str)
+		}
+		return nil
+	}
+
+	str, isZero, err := stringify(fv)
+	if err != nil {
+		return err
+	}
+	if omitempty && isZero {
+		return nil
+	}
+	values.Set(name, str)
+	return nil
+}
+
+var (
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+)
+
+// stringify converts a scalar field value into its string representation,
+// reporting whether it held its type's zero value (used for omitempty).
+func stringify(fv reflect.Value) (str string, isZero bool, err error) {
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			return "", true, nil
+		}
+		fv = fv.Elem()
+	}
+
+	if fv.Type() == timeType {
+		t := fv.Interface().(time.Time)
+		return t.Format(time.RFC3339), t.IsZero(), nil
+	}
+
+	if tm, ok := textMarshaler(fv); ok {
+		b, err := tm.MarshalText()
+		if err != nil {
+			return "", false, err
+		}
+		return string(b), len(b) == 0, nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		return fv.String(), fv.Len() == 0, nil
+	case reflect.Bool:
+		return strconv.FormatBool(fv.Bool()), !fv.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := fv.Int()
+		return strconv.FormatInt(n, 10), n == 0, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n := fv.Uint()
+		return strconv.FormatUint(n, 10), n == 0, nil
+	case reflect.Float32, reflect.Float64:
+		f := fv.Float()
+		return strconv.FormatFloat(f, 'f', -1, 64), f == 0, nil
+	default:
+		return "", false, fmt.Errorf("unsupported type %s", fv.Type())
+	}
+}
+
+// textMarshaler returns fv (or its address) as an [encoding.TextMarshaler],
+// if it implements that interface.
+func textMarshaler(fv reflect.Value) (encoding.TextMarshaler, bool) {
+	if fv.Type().Implements(textMarshalerType) {
+		tm, _ := fv.Interface().(encoding.TextMarshaler)
+		return tm, tm != nil
+	}
+	if fv.CanAddr() && fv.Addr().Type().Implements(textMarshalerType) {
+		tm, _ := fv.Addr().Interface().(encoding.TextMarshaler)
+		return tm, tm != nil
+	}
+	return nil, false
+}
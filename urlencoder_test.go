@@ -0,0 +1,86 @@
+package hrtclient_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"libdb.so/hrtclient"
+)
+
+type getUserReq struct {
+	ID      int       `path:"id"`
+	Include []string  `query:"include,omitempty"`
+	Since   time.Time `query:"since,omitempty"`
+	Auth    string    `header:"X-Auth,omitempty"`
+}
+
+func TestURLEncoder(t *testing.T) {
+	req, err := http.NewRequest("GET", "https://example.com/users/{id}", nil)
+	assert.NoError(t, err)
+
+	err = hrtclient.URLEncoder.Encode(req, getUserReq{
+		ID:      42,
+		Include: []string{"posts", "comments"},
+		Auth:    "token",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "/users/42", req.URL.Path)
+	assert.Equal(t, "include=posts&include=comments", req.URL.RawQuery)
+	assert.Equal(t, "token", req.Header.Get("X-Auth"))
+}
+
+type createUserForm struct {
+	Name string `query:"name"`
+	Bio  string `query:"bio,omitempty"`
+}
+
+func TestFormEncoder(t *testing.T) {
+	req, err := http.NewRequest("POST", "https://example.com/users", nil)
+	assert.NoError(t, err)
+
+	err = hrtclient.FormEncoder.Encode(req, createUserForm{Name: "ava"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "application/x-www-form-urlencoded", req.Header.Get("Content-Type"))
+
+	body, err := req.GetBody()
+	assert.NoError(t, err)
+	b := make([]byte, req.ContentLength)
+	_, err = body.Read(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "name=ava", string(b))
+}
+
+type uploadReq struct {
+	Name string    `query:"name"`
+	Tags []string  `query:"tags,omitempty"`
+	File io.Reader `multipart:"file"`
+}
+
+func TestMultipartEncoder(t *testing.T) {
+	req, err := http.NewRequest("POST", "https://example.com/upload", nil)
+	assert.NoError(t, err)
+
+	err = hrtclient.MultipartEncoder.Encode(req, uploadReq{
+		Name: "avatar",
+		Tags: []string{"a", "b"},
+		File: strings.NewReader("hello"),
+	})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/form-data; boundary="))
+
+	body, err := req.GetBody()
+	assert.NoError(t, err)
+	b := make([]byte, req.ContentLength)
+	_, err = body.Read(b)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), "hello")
+	assert.Contains(t, string(b), `name="file"`)
+	assert.Contains(t, string(b), `name="tags"`)
+	assert.Equal(t, 2, strings.Count(string(b), `name="tags"`))
+}